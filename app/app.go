@@ -20,28 +20,87 @@ type App interface {
 	Stop(force bool) error
 	Delete() error
 	SSH(...string) error
-	Status() error
+	Status() (StatusInfo, error)
 	Version() error
 	Runtime() (string, error)
 	Kubernetes() (environment.Container, error)
+	Snapshots() ([]environment.Snapshot, error)
+	CreateSnapshot(name string) error
+	RestoreSnapshot(name string) error
+	DeleteSnapshot(name string) error
+	Export(file string) error
+	Import(file string) error
 }
 
 var _ App = (*colimaApp)(nil)
 
+// VM states reported by status.
+const (
+	StateRunning    = "Running"
+	StateStopped    = "Stopped"
+	StateNotCreated = "NotCreated"
+)
+
+// StatusInfo is the status of a colima instance.
+type StatusInfo struct {
+	Profile       string         `json:"profile" yaml:"profile"`
+	State         string         `json:"state" yaml:"state"`
+	Arch          string         `json:"arch,omitempty" yaml:"arch,omitempty"`
+	CPU           int            `json:"cpu,omitempty" yaml:"cpu,omitempty"`
+	Memory        int            `json:"memory,omitempty" yaml:"memory,omitempty"` // in GiB
+	Disk          int            `json:"disk,omitempty" yaml:"disk,omitempty"`     // in GiB
+	Runtime       string         `json:"runtime,omitempty" yaml:"runtime,omitempty"`
+	DockerSocket  string         `json:"dockerSocket,omitempty" yaml:"dockerSocket,omitempty"`
+	Kubernetes    KubernetesInfo `json:"kubernetes" yaml:"kubernetes"`
+	NetworkDriver string         `json:"networkDriver,omitempty" yaml:"networkDriver,omitempty"`
+	IPAddress     string         `json:"ipAddress,omitempty" yaml:"ipAddress,omitempty"`
+	Mounts        []config.Mount `json:"mounts,omitempty" yaml:"mounts,omitempty"`
+}
+
+// Running returns if the instance is running.
+func (s StatusInfo) Running() bool { return s.State == StateRunning }
+
+// Created returns if the instance has been created.
+func (s StatusInfo) Created() bool { return s.State != "" && s.State != StateNotCreated }
+
+// KubernetesInfo is the status of kubernetes in a colima instance.
+type KubernetesInfo struct {
+	Enabled bool   `json:"enabled" yaml:"enabled"`
+	Version string `json:"version,omitempty" yaml:"version,omitempty"`
+	Context string `json:"context,omitempty" yaml:"context,omitempty"`
+}
+
 // New creates a new app.
+// The VM backend is determined by the vmType of the persisted config, defaults to lima.
 func New() (App, error) {
-	guest := lima.New(host.New())
+	conf, err := configmanager.Load()
+	if err != nil {
+		// not fatal, will proceed with the default vm type
+		log.Warnln(fmt.Errorf("config load failed: %w", err))
+	}
+
+	vmType := conf.VMType
+	if vmType == "" {
+		vmType = lima.Name
+	}
+
+	guest, err := environment.NewVM(vmType, host.New())
+	if err != nil {
+		return nil, err
+	}
 	if err := host.IsInstalled(guest); err != nil {
 		return nil, fmt.Errorf("dependency check failed for VM: %w", err)
 	}
 
 	return &colimaApp{
-		guest: guest,
+		guest:  guest,
+		vmType: vmType,
 	}, nil
 }
 
 type colimaApp struct {
-	guest environment.VM
+	guest  environment.VM
+	vmType string
 }
 
 func (c colimaApp) Start(conf config.Config) error {
@@ -73,6 +132,10 @@ func (c colimaApp) Start(conf config.Config) error {
 		containers = append(containers, env)
 	}
 
+	if err := c.runHooks(hookPreStart, conf.Hooks.PreStart, conf); err != nil {
+		return err
+	}
+
 	// the order for start is:
 	//   vm start -> container runtime provision -> container runtime start
 
@@ -96,6 +159,10 @@ func (c colimaApp) Start(conf config.Config) error {
 		log.Error(fmt.Errorf("error persisting runtime settings: %w", err))
 	}
 
+	if err := c.runHooks(hookPostStart, conf.Hooks.PostStart, conf); err != nil {
+		return err
+	}
+
 	log.Println("done")
 	return nil
 }
@@ -104,6 +171,15 @@ func (c colimaApp) Stop(force bool) error {
 	ctx := context.Background()
 	log.Println("stopping", config.Profile().DisplayName)
 
+	// the persisted config is the config used for the last startup
+	conf, err := configmanager.Load()
+	if err != nil {
+		log.Warnln(fmt.Errorf("config load failed: %w", err))
+	}
+	if err := c.runHooks(hookPreStop, conf.Hooks.PreStop, conf); err != nil {
+		return err
+	}
+
 	// the order for stop is:
 	//   container stop -> vm stop
 
@@ -140,6 +216,12 @@ func (c colimaApp) Delete() error {
 	ctx := context.Background()
 	log.Println("deleting", config.Profile().DisplayName)
 
+	// the config is deleted during teardown, retain for hooks
+	conf, err := configmanager.Load()
+	if err != nil {
+		log.Warnln(fmt.Errorf("config load failed: %w", err))
+	}
+
 	// the order for teardown is:
 	//   container teardown -> vm teardown
 
@@ -171,6 +253,10 @@ func (c colimaApp) Delete() error {
 		return fmt.Errorf("error deleting configs: %w", err)
 	}
 
+	if err := c.runHooks(hookPostDelete, conf.Hooks.PostDelete, conf); err != nil {
+		return err
+	}
+
 	log.Println("done")
 	return nil
 }
@@ -183,29 +269,54 @@ func (c colimaApp) SSH(args ...string) error {
 	return c.guest.RunInteractive(args...)
 }
 
-func (c colimaApp) Status() error {
+func (c colimaApp) Status() (StatusInfo, error) {
+	profile := config.Profile()
+	status := StatusInfo{
+		Profile: profile.ShortName,
+		State:   StateNotCreated,
+	}
+
+	if !c.guest.Created() {
+		return status, nil
+	}
+	status.State = StateStopped
+
+	// the persisted config is the config used for the last startup
+	conf, err := configmanager.Load()
+	if err != nil {
+		return status, fmt.Errorf("error loading config: %w", err)
+	}
+	status.CPU = conf.CPU
+	status.Memory = conf.Memory
+	status.Disk = conf.Disk
+	status.NetworkDriver = conf.Network.Driver
+	status.Mounts = conf.Mounts
+
 	if !c.guest.Running() {
-		return fmt.Errorf("%s is not running", config.Profile().DisplayName)
+		return status, nil
 	}
+	status.State = StateRunning
 
 	currentRuntime, err := c.currentRuntime()
 	if err != nil {
-		return err
+		return status, err
 	}
 
-	log.Println(config.Profile().DisplayName, "is running")
-	log.Println("arch:", c.guest.Arch())
-	log.Println("runtime:", currentRuntime)
+	status.Arch = string(c.guest.Arch())
+	status.Runtime = currentRuntime
 	if currentRuntime == docker.Name {
-		log.Println("socket:", "unix://"+docker.HostSocketFile())
+		status.DockerSocket = "unix://" + docker.HostSocketFile()
 	}
+	status.IPAddress = lima.IPAddress(profile.ID)
 
 	// kubernetes
 	if k, err := c.Kubernetes(); err == nil && k.Running() {
-		log.Println("kubernetes: enabled")
+		status.Kubernetes.Enabled = true
+		status.Kubernetes.Version = conf.Kubernetes.Version
+		status.Kubernetes.Context = profile.ID
 	}
 
-	return nil
+	return status, nil
 }
 
 func (c colimaApp) Version() error {
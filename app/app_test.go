@@ -0,0 +1,142 @@
+package app
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/abiosoft/colima/config"
+	"github.com/abiosoft/colima/config/configmanager"
+	"github.com/abiosoft/colima/environment/container/containerd"
+	"github.com/abiosoft/colima/environment/fake"
+)
+
+// setupHome sets an empty home directory for the test.
+func setupHome(t *testing.T) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	// config directory creation is only attempted once per process
+	if err := os.MkdirAll(config.Dir(), 0755); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func Test_colimaApp_lifecycle(t *testing.T) {
+	setupHome(t)
+
+	guest := fake.NewVM(fake.NewHost())
+	app := &colimaApp{guest: guest}
+
+	conf := config.Config{CPU: 4, Memory: 8, Disk: 100, Runtime: containerd.Name, VMType: fake.Name}
+	if err := configmanager.Save(conf); err != nil {
+		t.Fatal(err)
+	}
+
+	assertState := func(want string) StatusInfo {
+		t.Helper()
+		status, err := app.Status()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if status.State != want {
+			t.Fatalf("state = %s, want %s", status.State, want)
+		}
+		return status
+	}
+
+	assertState(StateNotCreated)
+
+	if err := app.Start(conf); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{
+		"sudo service containerd start",
+		"sudo service buildkitd start",
+		"sudo nerdctl info",
+	}; !reflect.DeepEqual(guest.Commands(), want) {
+		t.Errorf("commands = %q, want %q", guest.Commands(), want)
+	}
+	status := assertState(StateRunning)
+	if status.Runtime != containerd.Name {
+		t.Errorf("runtime = %s, want %s", status.Runtime, containerd.Name)
+	}
+	if status.CPU != conf.CPU || status.Memory != conf.Memory || status.Disk != conf.Disk {
+		t.Errorf("resources = %d/%d/%d, want %d/%d/%d", status.CPU, status.Memory, status.Disk, conf.CPU, conf.Memory, conf.Disk)
+	}
+	if status.DockerSocket != "" {
+		t.Errorf("docker socket = %s, want empty", status.DockerSocket)
+	}
+
+	if err := app.Stop(false); err != nil {
+		t.Fatal(err)
+	}
+	assertState(StateStopped)
+
+	if err := app.Delete(); err != nil {
+		t.Fatal(err)
+	}
+	assertState(StateNotCreated)
+}
+
+func Test_colimaApp_snapshot(t *testing.T) {
+	setupHome(t)
+
+	guest := fake.NewVM(fake.NewHost())
+	app := &colimaApp{guest: guest}
+
+	conf := config.Config{CPU: 2, Memory: 2, Disk: 60, Runtime: containerd.Name, VMType: fake.Name}
+	if err := configmanager.Save(conf); err != nil {
+		t.Fatal(err)
+	}
+	if err := app.Start(conf); err != nil {
+		t.Fatal(err)
+	}
+	if err := guest.Write("/data/file", "baseline"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := app.CreateSnapshot("../baseline"); err == nil {
+		t.Error("expected error for invalid snapshot name")
+	}
+	if err := app.CreateSnapshot("baseline"); err != nil {
+		t.Fatal(err)
+	}
+	if !guest.Running() {
+		t.Error("vm not restarted after snapshot")
+	}
+
+	// diverge from the snapshot
+	changed := conf
+	changed.CPU = 4
+	if err := configmanager.Save(changed); err != nil {
+		t.Fatal(err)
+	}
+	if err := guest.Write("/data/file", "changed"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := app.RestoreSnapshot("baseline"); err != nil {
+		t.Fatal(err)
+	}
+	if body, _ := guest.Read("/data/file"); body != "baseline" {
+		t.Errorf("file = %s, want baseline", body)
+	}
+	if restored, _ := configmanager.Load(); restored.CPU != conf.CPU {
+		t.Errorf("cpu = %d, want %d", restored.CPU, conf.CPU)
+	}
+
+	snapshots, err := app.Snapshots()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(snapshots) != 1 || snapshots[0].Name != "baseline" {
+		t.Errorf("snapshots = %+v, want [baseline]", snapshots)
+	}
+
+	if err := app.DeleteSnapshot("baseline"); err != nil {
+		t.Fatal(err)
+	}
+	if err := app.DeleteSnapshot("baseline"); err == nil {
+		t.Error("expected error deleting missing snapshot")
+	}
+}
@@ -0,0 +1,305 @@
+package app
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/abiosoft/colima/config"
+	"github.com/abiosoft/colima/config/configmanager"
+	"github.com/abiosoft/colima/environment"
+	log "github.com/sirupsen/logrus"
+)
+
+// manifestVersion is the current version of the archive format.
+const manifestVersion = 1
+
+const (
+	archiveManifestFile = "manifest.json"
+	archiveConfigFile   = "colima.yaml"
+	archiveVMDir        = "vm"
+)
+
+// Manifest describes an exported instance.
+type Manifest struct {
+	Version  int       `json:"version"`
+	Colima   string    `json:"colima"`
+	Profile  string    `json:"profile"`
+	VMType   string    `json:"vmType"`
+	Arch     string    `json:"arch"`
+	Runtime  string    `json:"runtime"`
+	Exported time.Time `json:"exported"`
+}
+
+// validate checks the compatibility of the archive with this installation.
+// Incompatible archives return an error, differences that are tolerated are logged.
+func (m Manifest) validate(vmType string) error {
+	if m.Version < 1 || m.Version > manifestVersion {
+		return fmt.Errorf("unsupported archive version %d, upgrade %s to import", m.Version, config.AppName)
+	}
+	if m.VMType != vmType {
+		return fmt.Errorf("archive vm type '%s' does not match '%s'", m.VMType, vmType)
+	}
+
+	supported := false
+	for _, r := range environment.ContainerRuntimes() {
+		supported = supported || r == m.Runtime
+	}
+	if !supported {
+		return fmt.Errorf("unsupported runtime '%s'", m.Runtime)
+	}
+
+	if host := environment.HostArch().Value(); environment.Arch(m.Arch).Value() != host {
+		log.Warnf("archive arch %s differs from host arch %s, the instance will be emulated", m.Arch, host)
+	}
+	if version := config.AppVersion().Version; m.Colima != version {
+		log.Warnf("archive exported with %s version %s, current version is %s", config.AppName, m.Colima, version)
+	}
+
+	return nil
+}
+
+func (c colimaApp) archivable() (environment.Archivable, error) {
+	a, ok := c.guest.(environment.Archivable)
+	if !ok {
+		return nil, fmt.Errorf("export not supported by the vm type")
+	}
+	return a, nil
+}
+
+func (c colimaApp) Export(file string) error {
+	a, err := c.archivable()
+	if err != nil {
+		return err
+	}
+	if !c.guest.Created() {
+		return fmt.Errorf("%s has not been created", config.Profile().DisplayName)
+	}
+
+	conf, err := configmanager.Load()
+	if err != nil {
+		return fmt.Errorf("error loading config: %w", err)
+	}
+
+	running := c.guest.Running()
+	if running {
+		if err := c.Stop(false); err != nil {
+			return err
+		}
+	}
+
+	log.Println("exporting", config.Profile().DisplayName, "to", file)
+	manifest := Manifest{
+		Version:  manifestVersion,
+		Colima:   config.AppVersion().Version,
+		Profile:  config.Profile().ShortName,
+		VMType:   c.vmType,
+		Arch:     string(environment.Arch(conf.Arch).Value()),
+		Runtime:  conf.Runtime,
+		Exported: time.Now(),
+	}
+	if err := writeArchive(file, manifest, a); err != nil {
+		_ = os.Remove(file)
+		return fmt.Errorf("error exporting: %w", err)
+	}
+
+	if running {
+		return c.Start(conf)
+	}
+
+	log.Println("done")
+	return nil
+}
+
+func (c colimaApp) Import(file string) error {
+	a, err := c.archivable()
+	if err != nil {
+		return err
+	}
+	if c.guest.Created() {
+		return fmt.Errorf("%s already exists, delete it or import as another profile", config.Profile().DisplayName)
+	}
+
+	manifest, err := ReadManifest(file)
+	if err != nil {
+		return err
+	}
+	if err := manifest.validate(c.vmType); err != nil {
+		return err
+	}
+
+	log.Println("importing", file, "as", config.Profile().DisplayName)
+	if err := extractArchive(file, a); err != nil {
+		// do not leave a partially imported instance behind
+		_ = os.RemoveAll(a.ArchiveDir())
+		_ = os.Remove(config.File())
+		return fmt.Errorf("error importing: %w", err)
+	}
+
+	log.Println("done")
+	return nil
+}
+
+// ReadManifest reads the manifest of an exported instance.
+func ReadManifest(file string) (m Manifest, err error) {
+	err = readArchive(file, func(name string, r io.Reader) (bool, error) {
+		if name != archiveManifestFile {
+			return false, fmt.Errorf("invalid archive: manifest not found")
+		}
+		if err := json.NewDecoder(r).Decode(&m); err != nil {
+			return false, fmt.Errorf("invalid archive manifest: %w", err)
+		}
+		return false, nil
+	})
+	return
+}
+
+func writeArchive(file string, manifest Manifest, a environment.Archivable) error {
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+
+	writeBytes := func(name string, b []byte) error {
+		hdr := &tar.Header{Name: name, Mode: 0644, Size: int64(len(b)), ModTime: manifest.Exported}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		_, err := tw.Write(b)
+		return err
+	}
+
+	// the manifest comes first to enable validation without a full read
+	b, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := writeBytes(archiveManifestFile, b); err != nil {
+		return err
+	}
+
+	// the config file as-is to preserve comments
+	b, err = os.ReadFile(config.File())
+	if err != nil {
+		return err
+	}
+	if err := writeBytes(archiveConfigFile, b); err != nil {
+		return err
+	}
+
+	for _, name := range a.ArchiveFiles() {
+		if err := writeFile(tw, filepath.Join(a.ArchiveDir(), name), path.Join(archiveVMDir, name)); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+func writeFile(tw *tar.Writer, src, name string) error {
+	f, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+
+	stat, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	hdr, err := tar.FileInfoHeader(stat, "")
+	if err != nil {
+		return err
+	}
+	hdr.Name = name
+	if err := tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+	_, err = io.Copy(tw, f)
+	return err
+}
+
+// readArchive calls fn for each file in the archive until fn returns false or an error.
+func readArchive(file string, fn func(name string, r io.Reader) (bool, error)) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return fmt.Errorf("error opening archive: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return fmt.Errorf("invalid archive: %w", err)
+	}
+	tr := tar.NewReader(gz)
+
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("invalid archive: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		next, err := fn(path.Clean(hdr.Name), tr)
+		if err != nil || !next {
+			return err
+		}
+	}
+}
+
+func extractArchive(file string, a environment.Archivable) error {
+	vmFiles := map[string]bool{}
+	for _, name := range a.ArchiveFiles() {
+		vmFiles[path.Join(archiveVMDir, name)] = true
+	}
+
+	if err := os.MkdirAll(a.ArchiveDir(), 0755); err != nil {
+		return err
+	}
+
+	return readArchive(file, func(name string, r io.Reader) (bool, error) {
+		var dst string
+		switch {
+		case name == archiveManifestFile:
+			return true, nil
+		case name == archiveConfigFile:
+			dst = config.File()
+		case vmFiles[name]:
+			dst = filepath.Join(a.ArchiveDir(), strings.TrimPrefix(name, archiveVMDir+"/"))
+		default:
+			log.Warnln("ignoring unknown archive file", name)
+			return true, nil
+		}
+
+		f, err := os.Create(dst)
+		if err != nil {
+			return false, err
+		}
+		if _, err := io.Copy(f, r); err != nil {
+			_ = f.Close()
+			return false, err
+		}
+		return true, f.Close()
+	})
+}
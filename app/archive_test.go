@@ -0,0 +1,105 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/abiosoft/colima/config"
+	"github.com/abiosoft/colima/environment"
+	"github.com/abiosoft/colima/environment/container/containerd"
+	"github.com/abiosoft/colima/environment/fake"
+)
+
+func TestManifest_validate(t *testing.T) {
+	valid := Manifest{
+		Version: manifestVersion,
+		VMType:  fake.Name,
+		Arch:    string(environment.HostArch().Value()),
+		Runtime: containerd.Name,
+	}
+
+	tests := []struct {
+		name     string
+		manifest func(m Manifest) Manifest
+		wantErr  bool
+	}{
+		{name: "valid", manifest: func(m Manifest) Manifest { return m }},
+		{name: "other arch", manifest: func(m Manifest) Manifest { m.Arch = "riscv64"; return m }},
+		{name: "newer version", manifest: func(m Manifest) Manifest { m.Version++; return m }, wantErr: true},
+		{name: "no version", manifest: func(m Manifest) Manifest { m.Version = 0; return m }, wantErr: true},
+		{name: "vm type", manifest: func(m Manifest) Manifest { m.VMType = "other"; return m }, wantErr: true},
+		{name: "runtime", manifest: func(m Manifest) Manifest { m.Runtime = "other"; return m }, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.manifest(valid).validate(fake.Name); (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+type dirArchivable string
+
+func (d dirArchivable) ArchiveDir() string     { return string(d) }
+func (d dirArchivable) ArchiveFiles() []string { return []string{"disk", "vm.yaml"} }
+
+func Test_archive(t *testing.T) {
+	setupHome(t)
+
+	src := dirArchivable(t.TempDir())
+	files := map[string]string{"disk": "disk contents", "vm.yaml": "cpus: 2"}
+	for name, body := range files {
+		if err := os.WriteFile(filepath.Join(src.ArchiveDir(), name), []byte(body), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// runtime files are not archived
+	if err := os.WriteFile(filepath.Join(src.ArchiveDir(), "ha.pid"), []byte("1"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	conf := "# comments are preserved\nruntime: containerd\n"
+	if err := os.WriteFile(config.File(), []byte(conf), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	file := filepath.Join(t.TempDir(), "out.tar.gz")
+	manifest := Manifest{Version: manifestVersion, Profile: "test", VMType: fake.Name, Runtime: containerd.Name, Exported: time.Now()}
+	if err := writeArchive(file, manifest, src); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ReadManifest(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Profile != manifest.Profile || got.Runtime != manifest.Runtime {
+		t.Errorf("manifest = %+v, want %+v", got, manifest)
+	}
+
+	if err := os.Remove(config.File()); err != nil {
+		t.Fatal(err)
+	}
+	dst := dirArchivable(filepath.Join(t.TempDir(), "instance"))
+	if err := extractArchive(file, dst); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, body := range files {
+		b, err := os.ReadFile(filepath.Join(dst.ArchiveDir(), name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(b) != body {
+			t.Errorf("%s = %s, want %s", name, b, body)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dst.ArchiveDir(), "ha.pid")); err == nil {
+		t.Error("runtime file archived")
+	}
+	if b, _ := os.ReadFile(config.File()); string(b) != conf {
+		t.Errorf("config = %s, want %s", b, conf)
+	}
+}
@@ -0,0 +1,52 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/abiosoft/colima/config"
+	"github.com/abiosoft/colima/environment/container/docker"
+	"github.com/abiosoft/colima/environment/vm/lima"
+	log "github.com/sirupsen/logrus"
+)
+
+// lifecycle stages for hooks
+const (
+	hookPreStart   = "preStart"
+	hookPostStart  = "postStart"
+	hookPreStop    = "preStop"
+	hookPostDelete = "postDelete"
+)
+
+// runHooks runs the host commands for the lifecycle stage.
+// An error is only returned for a failed fatal hook, other failures are logged.
+func (c colimaApp) runHooks(stage string, hooks []config.Hook, conf config.Config) error {
+	if len(hooks) == 0 {
+		return nil
+	}
+
+	profile := config.Profile()
+	env := []string{
+		config.SubprocessProfileEnvVar + "=" + profile.ShortName,
+		"COLIMA_HOOK=" + stage,
+	}
+	if conf.Runtime == docker.Name {
+		env = append(env, "COLIMA_DOCKER_SOCKET="+docker.HostSocketFile())
+	}
+	if c.guest.Running() {
+		env = append(env, "COLIMA_IP_ADDRESS="+lima.IPAddress(profile.ID))
+	}
+	host := c.guest.Host().WithEnv(env...)
+
+	log.Println("running", stage, "hooks")
+	for i, hook := range hooks {
+		if err := host.Run("sh", "-c", hook.Run); err != nil {
+			err = fmt.Errorf("error running %s hook %d: %w", stage, i, err)
+			if hook.Fatal {
+				return err
+			}
+			log.Warnln(err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/abiosoft/colima/config"
+	"github.com/abiosoft/colima/config/configmanager"
+	"github.com/abiosoft/colima/environment"
+	log "github.com/sirupsen/logrus"
+)
+
+var snapshotNameRegex = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+
+func (c colimaApp) snapshotter() (environment.Snapshotter, error) {
+	s, ok := c.guest.(environment.Snapshotter)
+	if !ok {
+		return nil, fmt.Errorf("snapshots not supported by the vm type")
+	}
+	if !c.guest.Created() {
+		return nil, fmt.Errorf("%s has not been created", config.Profile().DisplayName)
+	}
+	return s, nil
+}
+
+func (c colimaApp) Snapshots() ([]environment.Snapshot, error) {
+	s, err := c.snapshotter()
+	if err != nil {
+		return nil, err
+	}
+	return s.Snapshots()
+}
+
+func (c colimaApp) CreateSnapshot(name string) error {
+	if !snapshotNameRegex.MatchString(name) {
+		return fmt.Errorf("invalid snapshot name '%s'", name)
+	}
+	s, err := c.snapshotter()
+	if err != nil {
+		return err
+	}
+
+	conf, err := configmanager.Load()
+	if err != nil {
+		return fmt.Errorf("error loading config: %w", err)
+	}
+
+	running := c.guest.Running()
+	log.Println("creating snapshot", name)
+	if err := s.CreateSnapshot(context.Background(), name, conf); err != nil {
+		return fmt.Errorf("error creating snapshot: %w", err)
+	}
+
+	// the vm is stopped for the snapshot
+	if running {
+		return c.Start(conf)
+	}
+
+	log.Println("done")
+	return nil
+}
+
+func (c colimaApp) RestoreSnapshot(name string) error {
+	s, err := c.snapshotter()
+	if err != nil {
+		return err
+	}
+
+	running := c.guest.Running()
+	log.Println("restoring snapshot", name)
+	snapshot, err := s.RestoreSnapshot(context.Background(), name)
+	if err != nil {
+		return fmt.Errorf("error restoring snapshot: %w", err)
+	}
+
+	if err := configmanager.Save(snapshot.Config); err != nil {
+		return fmt.Errorf("error restoring config: %w", err)
+	}
+
+	// the vm is stopped for the restore
+	if running {
+		return c.Start(snapshot.Config)
+	}
+
+	log.Println("done")
+	return nil
+}
+
+func (c colimaApp) DeleteSnapshot(name string) error {
+	s, err := c.snapshotter()
+	if err != nil {
+		return err
+	}
+	return s.DeleteSnapshot(name)
+}
@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"github.com/abiosoft/colima/cmd/root"
+	"github.com/abiosoft/colima/config"
+	"github.com/spf13/cobra"
+)
+
+// exportCmd represents the export command
+var exportCmd = &cobra.Command{
+	Use:   "export [profile] <file>",
+	Short: "export an instance to an archive",
+	Long: `Export an instance to a gzipped tar archive.
+
+The archive contains the configuration and the disk of the instance,
+and can be imported on another machine with 'colima import'.
+The instance is stopped during the export and started afterwards if it was previously running.`,
+	Example: "  colima export colima.tar.gz\n" +
+		"  colima export myprofile myprofile.tar.gz",
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file := args[len(args)-1]
+		if len(args) > 1 && !cmd.Flag("profile").Changed {
+			config.SetProfile(args[0])
+		}
+
+		return newApp().Export(file)
+	},
+}
+
+func init() {
+	root.Cmd().AddCommand(exportCmd)
+}
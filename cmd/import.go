@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"github.com/abiosoft/colima/app"
+	"github.com/abiosoft/colima/cmd/root"
+	"github.com/abiosoft/colima/config"
+	"github.com/spf13/cobra"
+)
+
+// importCmd represents the import command
+var importCmd = &cobra.Command{
+	Use:   "import <file> [profile]",
+	Short: "import an instance from an archive",
+	Long: `Import an instance from an archive created with 'colima export'.
+
+The instance is imported as the profile it was exported from, unless a profile is specified.
+The profile must not already exist. Run 'colima start' afterwards to start the instance.`,
+	Example: "  colima import colima.tar.gz\n" +
+		"  colima import colima.tar.gz myprofile",
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file := args[0]
+
+		if !cmd.Flag("profile").Changed {
+			profile := ""
+			if len(args) > 1 {
+				profile = args[1]
+			} else {
+				manifest, err := app.ReadManifest(file)
+				if err != nil {
+					return err
+				}
+				profile = manifest.Profile
+			}
+			config.SetProfile(profile)
+		}
+
+		return newApp().Import(file)
+	},
+}
+
+func init() {
+	root.Cmd().AddCommand(importCmd)
+}
@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/abiosoft/colima/cli"
+	"github.com/abiosoft/colima/cmd/root"
+	"github.com/abiosoft/colima/config"
+	"github.com/docker/go-units"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+// snapshotCmd represents the snapshot command
+var snapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "manage instance snapshots",
+	Long: `Manage snapshots of the instance.
+
+A snapshot is a copy of the disk, configuration and runtime settings of the instance.
+The instance is stopped while a snapshot is created or restored, and started afterwards
+if it was previously running.`,
+}
+
+// snapshotCreateCmd represents the snapshot create command
+var snapshotCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "create a snapshot",
+	Long:  `Create a snapshot of the instance.`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return newApp().CreateSnapshot(args[0])
+	},
+}
+
+var snapshotListCmdArgs struct {
+	json bool
+}
+
+// snapshotListCmd represents the snapshot list command
+var snapshotListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "list snapshots",
+	Long:    `List the snapshots of the instance.`,
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		snapshots, err := newApp().Snapshots()
+		if err != nil {
+			return err
+		}
+
+		if snapshotListCmdArgs.json {
+			encoder := json.NewEncoder(cmd.OutOrStdout())
+			for _, s := range snapshots {
+				if err := encoder.Encode(s); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+
+		w := tabwriter.NewWriter(cmd.OutOrStdout(), 4, 8, 4, ' ', 0)
+		_, _ = fmt.Fprintln(w, "NAME\tCREATED\tSIZE\tRUNTIME\tCPUS\tMEMORY\tDISK")
+
+		if len(snapshots) == 0 {
+			logrus.Warn("No snapshot found. Run `colima snapshot create <name>` to create a snapshot.")
+		}
+
+		for _, s := range snapshots {
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%dGiB\t%dGiB\n",
+				s.Name,
+				s.Created.Format(time.RFC3339),
+				units.BytesSize(float64(s.Size)),
+				s.Config.Runtime,
+				s.Config.CPU,
+				s.Config.Memory,
+				s.Config.Disk,
+			)
+		}
+
+		return w.Flush()
+	},
+}
+
+var snapshotRestoreCmdArgs struct {
+	force bool
+}
+
+// snapshotRestoreCmd represents the snapshot restore command
+var snapshotRestoreCmd = &cobra.Command{
+	Use:   "restore <name>",
+	Short: "restore a snapshot",
+	Long: `Restore a snapshot of the instance.
+
+All changes made to the instance after the snapshot was created are lost.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if !snapshotRestoreCmdArgs.force {
+			y := cli.Prompt("are you sure you want to restore " + config.Profile().DisplayName + " to snapshot '" + args[0] + "'")
+			if !y {
+				return nil
+			}
+		}
+
+		return newApp().RestoreSnapshot(args[0])
+	},
+}
+
+// snapshotDeleteCmd represents the snapshot delete command
+var snapshotDeleteCmd = &cobra.Command{
+	Use:     "delete <name>",
+	Aliases: []string{"rm"},
+	Short:   "delete a snapshot",
+	Long:    `Delete a snapshot of the instance.`,
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return newApp().DeleteSnapshot(args[0])
+	},
+}
+
+func init() {
+	root.Cmd().AddCommand(snapshotCmd)
+	snapshotCmd.AddCommand(snapshotCreateCmd)
+	snapshotCmd.AddCommand(snapshotListCmd)
+	snapshotCmd.AddCommand(snapshotRestoreCmd)
+	snapshotCmd.AddCommand(snapshotDeleteCmd)
+
+	snapshotListCmd.Flags().BoolVarP(&snapshotListCmdArgs.json, "json", "j", false, "print json output")
+	snapshotRestoreCmd.Flags().BoolVarP(&snapshotRestoreCmdArgs.force, "force", "f", false, "do not prompt for yes/no")
+}
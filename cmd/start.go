@@ -14,6 +14,7 @@ import (
 	"github.com/abiosoft/colima/environment"
 	"github.com/abiosoft/colima/environment/container/docker"
 	"github.com/abiosoft/colima/environment/container/kubernetes"
+	"github.com/abiosoft/colima/environment/vm/lima"
 	"github.com/abiosoft/colima/util"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -41,26 +42,37 @@ Run 'colima template' to set the default configurations or 'colima start --edit'
 		app := newApp()
 		conf := startCmdArgs.Config
 
-		if !startCmdArgs.Flags.Edit {
-			if app.Active() {
-				log.Warnln("already running, ignoring")
-				return nil
+		if startCmdArgs.Flags.Edit {
+			// edit flag is specified
+			err := editConfigFile()
+			if err != nil {
+				return err
 			}
-			return app.Start(conf)
-		}
 
-		// edit flag is specified
-		err := editConfigFile()
-		if err != nil {
-			return err
+			conf, err = configmanager.Load()
+			if err != nil {
+				return fmt.Errorf("error opening config file: %w", err)
+			}
 		}
 
-		conf, err = configmanager.Load()
-		if err != nil {
-			return fmt.Errorf("error opening config file: %w", err)
+		// changes only need to be reconciled for an existing instance
+		var changes []configChange
+		if status, err := app.Status(); err == nil && status.Created() {
+			changes = diffConfig(startCmdArgs.current, conf)
+		}
+		if err := reportConfigChanges(changes); err != nil {
+			// revert to the config of the existing instance
+			if err := configmanager.Save(startCmdArgs.current); err != nil {
+				log.Warnln(fmt.Errorf("error reverting config file: %w", err))
+			}
+			return err
 		}
 
 		if app.Active() {
+			if !startCmdArgs.Flags.Edit && len(changes) == 0 {
+				log.Warnln("already running, ignoring")
+				return nil
+			}
 			if !cli.Prompt("colima is currently running, restart to apply changes") {
 				return nil
 			}
@@ -92,11 +104,15 @@ const (
 	defaultDisk              = 60
 	defaultKubernetesVersion = kubernetes.DefaultVersion
 	defaultDriver            = config.GVProxyDriver
+	defaultVMType            = lima.Name
 )
 
 var startCmdArgs struct {
 	config.Config
 
+	// current is the persisted config prior to startup.
+	current config.Config
+
 	Flags struct {
 		Mounts           []string
 		LegacyKubernetes bool // for backward compatibility
@@ -107,6 +123,7 @@ var startCmdArgs struct {
 
 func init() {
 	runtimes := strings.Join(environment.ContainerRuntimes(), ", ")
+	vmTypes := strings.Join(environment.VMTypes(), ", ")
 	defaultArch := string(environment.HostArch().Value())
 
 	root.Cmd().AddCommand(startCmd)
@@ -116,6 +133,7 @@ func init() {
 	startCmd.Flags().IntVarP(&startCmdArgs.Memory, "memory", "m", defaultMemory, "memory in GiB")
 	startCmd.Flags().IntVarP(&startCmdArgs.Disk, "disk", "d", defaultDisk, "disk size in GiB")
 	startCmd.Flags().StringVarP(&startCmdArgs.Arch, "arch", "a", defaultArch, "architecture (aarch64, x86_64)")
+	startCmd.Flags().StringVar(&startCmdArgs.VMType, "vm-type", defaultVMType, "virtual machine type ("+vmTypes+")")
 
 	// network
 	if util.MacOS() {
@@ -167,6 +185,7 @@ func prepareConfig(cmd *cobra.Command) {
 		log.Warnln(fmt.Errorf("config load failed: %w", err))
 		log.Warnln("reverting to default settings")
 	}
+	startCmdArgs.current = current
 
 	// handle legacy kubernetes flag
 	if cmd.Flag("with-kubernetes").Changed {
@@ -229,6 +248,9 @@ func prepareConfig(cmd *cobra.Command) {
 	if !cmd.Flag("env").Changed {
 		startCmdArgs.Env = current.Env
 	}
+	if !cmd.Flag("vm-type").Changed {
+		startCmdArgs.VMType = current.VMType
+	}
 	if util.MacOS() {
 		if !cmd.Flag("network-address").Changed {
 			startCmdArgs.Network.Address = current.Network.Address
@@ -268,3 +290,93 @@ func editConfigFile() error {
 	}()
 	return configmanager.SaveFromFile(tmpFile)
 }
+
+// changeAction is the action required to apply a config change to an existing instance.
+type changeAction int
+
+const (
+	changeOnRestart changeAction = iota
+	changeDiskGrow
+	changeImpossible
+)
+
+// configChange is a change to the config of an existing instance.
+type configChange struct {
+	Field  string
+	From   string
+	To     string
+	Action changeAction
+}
+
+// diffConfig returns the changes between the current config of an existing instance
+// and the requested config.
+func diffConfig(current, conf config.Config) []configChange {
+	if current.Empty() {
+		return nil
+	}
+
+	var changes []configChange
+	add := func(field string, from, to any, action changeAction) {
+		changes = append(changes, configChange{
+			Field:  field,
+			From:   fmt.Sprint(from),
+			To:     fmt.Sprint(to),
+			Action: action,
+		})
+	}
+
+	if from, to := environment.Arch(current.Arch).Value(), environment.Arch(conf.Arch).Value(); from != to {
+		add("arch", from, to, changeImpossible)
+	}
+	if from, to := vmType(current), vmType(conf); from != to {
+		add("vmType", from, to, changeImpossible)
+	}
+	if from, to := current.Disk, conf.Disk; from > 0 && to > 0 && from != to {
+		if to < from {
+			add("disk", from, to, changeImpossible)
+		} else {
+			add("disk", from, to, changeDiskGrow)
+		}
+	}
+	if current.CPU != conf.CPU {
+		add("cpu", current.CPU, conf.CPU, changeOnRestart)
+	}
+	if current.CPUType != conf.CPUType {
+		add("cpuType", current.CPUType, conf.CPUType, changeOnRestart)
+	}
+	if current.Memory != conf.Memory {
+		add("memory", current.Memory, conf.Memory, changeOnRestart)
+	}
+
+	return changes
+}
+
+func vmType(conf config.Config) string {
+	if conf.VMType == "" {
+		return defaultVMType
+	}
+	return conf.VMType
+}
+
+// reportConfigChanges logs the config changes and how they would be applied.
+// An error is returned if any of the changes cannot be applied.
+func reportConfigChanges(changes []configChange) error {
+	var impossible []string
+	for _, c := range changes {
+		change := fmt.Sprintf("%s: %s -> %s", c.Field, c.From, c.To)
+		switch c.Action {
+		case changeOnRestart:
+			log.Println(change + ", applied on restart")
+		case changeDiskGrow:
+			log.Println(change + ", disk will be resized")
+		case changeImpossible:
+			log.Errorln(change + ", cannot be changed for an existing instance")
+			impossible = append(impossible, c.Field)
+		}
+	}
+
+	if len(impossible) > 0 {
+		return fmt.Errorf("cannot change %s, delete the instance with 'colima delete' to apply", strings.Join(impossible, ", "))
+	}
+	return nil
+}
@@ -48,3 +48,73 @@ func Test_mountsFromFlag(t *testing.T) {
 		})
 	}
 }
+
+func Test_diffConfig(t *testing.T) {
+	current := config.Config{Runtime: "docker", CPU: 2, Memory: 2, Disk: 60, Arch: "x86_64"}
+
+	tests := []struct {
+		name string
+		conf func(c config.Config) config.Config
+		want []configChange
+	}{
+		{
+			name: "unchanged",
+			conf: func(c config.Config) config.Config { return c },
+		},
+		{
+			name: "arch alias",
+			conf: func(c config.Config) config.Config { c.Arch = "amd64"; return c },
+		},
+		{
+			name: "resources",
+			conf: func(c config.Config) config.Config { c.CPU = 4; c.Memory = 8; return c },
+			want: []configChange{
+				{Field: "cpu", From: "2", To: "4", Action: changeOnRestart},
+				{Field: "memory", From: "2", To: "8", Action: changeOnRestart},
+			},
+		},
+		{
+			name: "disk grow",
+			conf: func(c config.Config) config.Config { c.Disk = 100; return c },
+			want: []configChange{
+				{Field: "disk", From: "60", To: "100", Action: changeDiskGrow},
+			},
+		},
+		{
+			name: "disk shrink",
+			conf: func(c config.Config) config.Config { c.Disk = 30; return c },
+			want: []configChange{
+				{Field: "disk", From: "60", To: "30", Action: changeImpossible},
+			},
+		},
+		{
+			name: "arch",
+			conf: func(c config.Config) config.Config { c.Arch = "aarch64"; return c },
+			want: []configChange{
+				{Field: "arch", From: "x86_64", To: "aarch64", Action: changeImpossible},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := diffConfig(current, tt.conf(current)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("diffConfig() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_reportConfigChanges(t *testing.T) {
+	changes := []configChange{
+		{Field: "cpu", From: "2", To: "4", Action: changeOnRestart},
+		{Field: "disk", From: "60", To: "100", Action: changeDiskGrow},
+	}
+	if err := reportConfigChanges(changes); err != nil {
+		t.Errorf("reportConfigChanges() unexpected error: %v", err)
+	}
+
+	changes = append(changes, configChange{Field: "arch", From: "x86_64", To: "aarch64", Action: changeImpossible})
+	if err := reportConfigChanges(changes); err == nil {
+		t.Errorf("reportConfigChanges() expected error for arch change")
+	}
+}
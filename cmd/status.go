@@ -1,21 +1,110 @@
 package cmd
 
 import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/abiosoft/colima/app"
 	"github.com/abiosoft/colima/cmd/root"
+	"github.com/abiosoft/colima/config"
+	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
 )
 
+var statusCmdArgs struct {
+	json   bool
+	format string
+}
+
 // statusCmd represents the status command
 var statusCmd = &cobra.Command{
 	Use:   "status [profile]",
 	Short: "show the status of Colima",
-	Long:  `Show the status of Colima`,
-	Args:  cobra.MaximumNArgs(1),
+	Long: `Show the status of Colima.
+
+The status can be printed in a machine-readable format with '--format json' or '--format yaml'.`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return newApp().Status()
+		format := statusCmdArgs.format
+		if statusCmdArgs.json {
+			format = "json"
+		}
+
+		status, err := newApp().Status()
+		if err != nil {
+			return err
+		}
+
+		switch format {
+		case "json":
+			enc := json.NewEncoder(cmd.OutOrStdout())
+			enc.SetIndent("", "  ")
+			if err := enc.Encode(status); err != nil {
+				return fmt.Errorf("error encoding status: %w", err)
+			}
+		case "yaml":
+			enc := yaml.NewEncoder(cmd.OutOrStdout())
+			enc.SetIndent(2)
+			if err := enc.Encode(status); err != nil {
+				return fmt.Errorf("error encoding status: %w", err)
+			}
+		case "", "text":
+			printStatus(status)
+		default:
+			return fmt.Errorf("invalid format '%s', must be one of text, json, yaml", format)
+		}
+
+		if !status.Running() {
+			return fmt.Errorf("%s is not running", config.Profile().DisplayName)
+		}
+		return nil
 	},
 }
 
+// printStatus prints the status in the human-readable format.
+func printStatus(status app.StatusInfo) {
+	if !status.Running() {
+		return
+	}
+
+	log.Println(config.Profile().DisplayName, "is running")
+	log.Println("arch:", status.Arch)
+	log.Println("runtime:", status.Runtime)
+	log.Printf("cpu: %d, memory: %dGiB, disk: %dGiB", status.CPU, status.Memory, status.Disk)
+	if status.DockerSocket != "" {
+		log.Println("socket:", status.DockerSocket)
+	}
+	if status.NetworkDriver != "" {
+		log.Println("network driver:", status.NetworkDriver)
+	}
+	if status.IPAddress != "" {
+		log.Println("address:", status.IPAddress)
+	}
+	if len(status.Mounts) > 0 {
+		var mounts []string
+		for _, m := range status.Mounts {
+			mount := m.Location
+			if m.Writable {
+				mount += ":w"
+			}
+			mounts = append(mounts, mount)
+		}
+		log.Println("mounts:", strings.Join(mounts, ", "))
+	}
+
+	// kubernetes
+	if status.Kubernetes.Enabled {
+		log.Println("kubernetes: enabled")
+		log.Println("kubernetes version:", status.Kubernetes.Version)
+		log.Println("kubernetes context:", status.Kubernetes.Context)
+	}
+}
+
 func init() {
 	root.Cmd().AddCommand(statusCmd)
+
+	statusCmd.Flags().StringVarP(&statusCmdArgs.format, "format", "f", "text", "output format (text, json, yaml)")
+	statusCmd.Flags().BoolVarP(&statusCmdArgs.json, "json", "j", false, "print json output, shorthand for '--format json'")
 }
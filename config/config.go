@@ -66,6 +66,9 @@ type Config struct {
 	DNS          []net.IP          `yaml:"dns,omitempty"` // DNS nameservers
 	Env          map[string]string `yaml:"env,omitempty"` // environment variables
 
+	// VMType is the virtual machine backend e.g. lima.
+	VMType string `yaml:"vmType,omitempty"`
+
 	// volume mounts
 	Mounts    []Mount `yaml:"mounts,omitempty"`
 	MountType string  `yaml:"mountType,omitempty"`
@@ -78,6 +81,12 @@ type Config struct {
 
 	// Docker configuration
 	Docker map[string]any `yaml:"docker,omitempty"`
+
+	// Provision scripts
+	Provision []Provision `yaml:"provision,omitempty"`
+
+	// Hooks are host commands for lifecycle stages
+	Hooks Hooks `yaml:"hooks,omitempty"`
 }
 
 // Kubernetes is kubernetes configuration
@@ -99,10 +108,41 @@ type Network struct {
 	Driver  string `yaml:"driver"`
 }
 
+// Provision modes
+const (
+	ProvisionModeSystem = "system"
+	ProvisionModeUser   = "user"
+)
+
+// Provision is a provision script for the VM.
+type Provision struct {
+	// Mode is one of system (as root), user (as the default user).
+	Mode   string `yaml:"mode"`
+	Script string `yaml:"script"`
+	// Once restricts the script to a single successful run, rather than on every boot.
+	Once bool `yaml:"once,omitempty"`
+}
+
+// Hooks are commands executed on the host at stages of the instance lifecycle.
+type Hooks struct {
+	PreStart   []Hook `yaml:"preStart,omitempty"`
+	PostStart  []Hook `yaml:"postStart,omitempty"`
+	PreStop    []Hook `yaml:"preStop,omitempty"`
+	PostDelete []Hook `yaml:"postDelete,omitempty"`
+}
+
+// Hook is a command executed on the host.
+type Hook struct {
+	// Run is the command, executed with sh.
+	Run string `yaml:"run"`
+	// Fatal aborts on failure, failures are otherwise only logged.
+	Fatal bool `yaml:"fatal,omitempty"`
+}
+
 // Mount is volume mount
 type Mount struct {
-	Location string `yaml:"location"`
-	Writable bool   `yaml:"writable"`
+	Location string `yaml:"location" json:"location"`
+	Writable bool   `yaml:"writable" json:"writable"`
 }
 
 // CleanPath returns the absolute path to the mount location.
@@ -0,0 +1,23 @@
+#!/usr/bin/env sh
+# colima provision script #{.Index}}
+
+marker="#{.MarkerFile}}"
+#{- if .Once}}
+# already provisioned
+[ -f "$marker" ] && exit 0
+#{- end}}
+
+log="$(mktemp)"
+(
+#{.Script}}
+) >"$log" 2>&1
+status=$?
+[ $status -ne 0 ] && echo "failed with exit status $status" >>"$log"
+
+sudo mkdir -p "$(dirname "#{.LogFile}}")"
+sudo mv "$log" "#{.LogFile}}"
+#{- if .Once}}
+[ $status -eq 0 ] && sudo mkdir -p "$(dirname "$marker")" && sudo touch "$marker"
+#{- end}}
+
+exit $status
@@ -0,0 +1,48 @@
+package containerd
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/abiosoft/colima/environment/fake"
+)
+
+func Test_containerdRuntime_Start(t *testing.T) {
+	host := fake.NewHost()
+	guest := fake.NewGuest()
+	c := newRuntime(host, guest)
+
+	if err := c.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"sudo service containerd start",
+		"sudo service buildkitd start",
+		"sudo nerdctl info",
+	}
+	if got := guest.Commands(); !reflect.DeepEqual(got, want) {
+		t.Errorf("commands:\ngot  %q\nwant %q", got, want)
+	}
+	if got := host.Commands(); len(got) > 0 {
+		t.Errorf("unexpected host commands: %q", got)
+	}
+}
+
+func Test_containerdRuntime_Stop(t *testing.T) {
+	host := fake.NewHost()
+	guest := fake.NewGuest()
+	c := newRuntime(host, guest)
+
+	if err := c.Stop(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"sudo service containerd stop",
+	}
+	if got := guest.Commands(); !reflect.DeepEqual(got, want) {
+		t.Errorf("commands:\ngot  %q\nwant %q", got, want)
+	}
+}
@@ -0,0 +1,105 @@
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/abiosoft/colima/config"
+	"github.com/abiosoft/colima/environment/fake"
+)
+
+func newTestRuntime(t *testing.T) (*dockerRuntime, *fake.Host, *fake.Guest) {
+	t.Setenv("HOME", t.TempDir())
+	host := fake.NewHost()
+	guest := fake.NewGuest()
+	return newRuntime(host, guest).(*dockerRuntime), host, guest
+}
+
+func assertCommands(t *testing.T, name string, got, want []string) {
+	t.Helper()
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("%s commands:\ngot  %q\nwant %q", name, got, want)
+	}
+}
+
+func Test_dockerRuntime_Provision(t *testing.T) {
+	d, host, guest := newTestRuntime(t)
+	// context does not exist
+	host.Respond("sh -c docker context ls", "", errors.New("exit status 1"))
+
+	conf := config.Config{Docker: map[string]any{"insecure-registries": []any{"127.0.0.1"}}}
+	ctx := context.WithValue(context.Background(), config.CtxKey(), conf)
+	if err := d.Provision(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	assertCommands(t, "guest", guest.Commands(), nil)
+	assertCommands(t, "host", host.Commands(), []string{
+		`sh -c docker context ls -q | grep "^colima$"`,
+		"docker context create colima --description colima --docker host=unix://" + HostSocketFile(),
+		"docker context use colima",
+	})
+
+	b, err := guest.Read(daemonFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var daemon map[string]any
+	if err := json.Unmarshal([]byte(b), &daemon); err != nil {
+		t.Fatalf("invalid daemon.json: %v", err)
+	}
+	for _, key := range []string{"features", "exec-opts", "insecure-registries"} {
+		if _, ok := daemon[key]; !ok {
+			t.Errorf("daemon.json missing key '%s'", key)
+		}
+	}
+}
+
+func Test_dockerRuntime_Start(t *testing.T) {
+	d, host, guest := newTestRuntime(t)
+
+	if err := d.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	assertCommands(t, "host", host.Commands(), nil)
+	assertCommands(t, "guest", guest.Commands(), []string{
+		"sudo service docker start",
+		"sudo docker info",
+	})
+}
+
+func Test_dockerRuntime_Stop(t *testing.T) {
+	d, host, guest := newTestRuntime(t)
+
+	if err := d.Stop(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	assertCommands(t, "guest", guest.Commands(), []string{
+		"service docker status",
+		"sudo service docker stop",
+	})
+	assertCommands(t, "host", host.Commands(), []string{
+		`sh -c docker context ls -q | grep "^colima$"`,
+		"docker context rm --force colima",
+	})
+}
+
+func Test_dockerRuntime_Teardown(t *testing.T) {
+	d, host, guest := newTestRuntime(t)
+	// context does not exist
+	host.Respond("sh -c docker context ls", "", errors.New("exit status 1"))
+
+	if err := d.Teardown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	assertCommands(t, "guest", guest.Commands(), nil)
+	assertCommands(t, "host", host.Commands(), []string{
+		`sh -c docker context ls -q | grep "^colima$"`,
+	})
+}
@@ -0,0 +1,65 @@
+package kubernetes
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/abiosoft/colima/environment"
+	"github.com/abiosoft/colima/environment/container/containerd"
+	"github.com/abiosoft/colima/environment/container/docker"
+	"github.com/abiosoft/colima/environment/fake"
+)
+
+func Test_kubernetesRuntime_Stop(t *testing.T) {
+	tests := []struct {
+		runtime string
+		ids     string
+		want    []string
+	}{
+		{
+			runtime: containerd.Name,
+			ids:     "abc\ndef",
+			want: []string{
+				"k3s-killall.sh",
+				"sudo nerdctl -n k8s.io ps -q",
+				"sudo sh -c nerdctl -n k8s.io kill abc def",
+			},
+		},
+		{
+			runtime: docker.Name,
+			ids:     "k8s_abc",
+			want: []string{
+				"k3s-killall.sh",
+				`sudo sh -c docker ps --format '{{.Names}}'| grep "k8s_"`,
+				"sudo sh -c docker kill k8s_abc",
+			},
+		},
+		{
+			runtime: containerd.Name,
+			want: []string{
+				"k3s-killall.sh",
+				"sudo nerdctl -n k8s.io ps -q",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.runtime, func(t *testing.T) {
+			guest := fake.NewGuest()
+			if err := guest.Set(environment.ContainerRuntimeKey, tt.runtime); err != nil {
+				t.Fatal(err)
+			}
+			guest.Respond("sudo nerdctl -n k8s.io ps", tt.ids, nil)
+			guest.Respond("sudo sh -c docker ps", tt.ids, nil)
+
+			c := newRuntime(fake.NewHost(), guest)
+			if err := c.Stop(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+
+			if got := guest.Commands(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("commands:\ngot  %q\nwant %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+// Package fake provides in-memory implementations of the environment actions
+// for testing without a host or a virtual machine.
+//
+// Commands are recorded in order of execution and their responses can be scripted.
+// File actions are backed by a virtual filesystem.
+package fake
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+type response struct {
+	prefix string
+	output string
+	err    error
+}
+
+// recorder records commands and serves scripted responses.
+type recorder struct {
+	sync.Mutex
+	commands  []string
+	responses []response
+	files     map[string]string
+}
+
+func newRecorder() *recorder {
+	return &recorder{files: map[string]string{}}
+}
+
+func (r *recorder) run(args ...string) (string, error) {
+	if len(args) == 0 {
+		return "", fmt.Errorf("args not specified")
+	}
+
+	r.Lock()
+	defer r.Unlock()
+
+	command := strings.Join(args, " ")
+	r.commands = append(r.commands, command)
+
+	// the most recently scripted response takes precedence
+	for i := len(r.responses) - 1; i >= 0; i-- {
+		if resp := r.responses[i]; strings.HasPrefix(command, resp.prefix) {
+			return resp.output, resp.err
+		}
+	}
+	return "", nil
+}
+
+// Respond scripts the output and error for commands beginning with prefix.
+// The prefix is matched against the command arguments joined by a space.
+func (r *recorder) Respond(prefix string, output string, err error) {
+	r.Lock()
+	defer r.Unlock()
+	r.responses = append(r.responses, response{prefix: prefix, output: output, err: err})
+}
+
+// Commands returns the executed commands, each with the arguments joined by a space.
+func (r *recorder) Commands() []string {
+	r.Lock()
+	defer r.Unlock()
+	return append([]string(nil), r.commands...)
+}
+
+// Reset clears the recorded commands.
+// Scripted responses and files are retained.
+func (r *recorder) Reset() {
+	r.Lock()
+	defer r.Unlock()
+	r.commands = nil
+}
+
+func (r *recorder) Run(args ...string) error {
+	_, err := r.run(args...)
+	return err
+}
+
+func (r *recorder) RunQuiet(args ...string) error {
+	_, err := r.run(args...)
+	return err
+}
+
+func (r *recorder) RunOutput(args ...string) (string, error) {
+	return r.run(args...)
+}
+
+func (r *recorder) RunInteractive(args ...string) error {
+	_, err := r.run(args...)
+	return err
+}
+
+func (r *recorder) RunWith(stdin io.Reader, stdout io.Writer, args ...string) error {
+	out, err := r.run(args...)
+	if err != nil {
+		return err
+	}
+	if stdout != nil {
+		_, err = io.WriteString(stdout, out)
+	}
+	return err
+}
+
+func (r *recorder) Read(fileName string) (string, error) {
+	r.Lock()
+	defer r.Unlock()
+	body, ok := r.files[filepath.Clean(fileName)]
+	if !ok {
+		return "", fmt.Errorf("cannot read file: %s", fileName)
+	}
+	return body, nil
+}
+
+func (r *recorder) Write(fileName, body string) error {
+	r.Lock()
+	defer r.Unlock()
+	r.files[filepath.Clean(fileName)] = body
+	return nil
+}
+
+func (r *recorder) Stat(fileName string) (os.FileInfo, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	fileName = filepath.Clean(fileName)
+	if body, ok := r.files[fileName]; ok {
+		return fileInfo{name: filepath.Base(fileName), size: int64(len(body))}, nil
+	}
+
+	// directories are implied by the files in them
+	for f := range r.files {
+		if strings.HasPrefix(f, fileName+"/") {
+			return fileInfo{name: filepath.Base(fileName), dir: true}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("cannot stat file: %s", fileName)
+}
+
+var _ os.FileInfo = (*fileInfo)(nil)
+
+type fileInfo struct {
+	name string
+	size int64
+	dir  bool
+}
+
+// IsDir implements fs.FileInfo
+func (f fileInfo) IsDir() bool { return f.dir }
+
+// ModTime implements fs.FileInfo
+func (f fileInfo) ModTime() time.Time { return time.Time{} }
+
+// Mode implements fs.FileInfo
+func (f fileInfo) Mode() fs.FileMode {
+	if f.dir {
+		return fs.ModeDir | 0755
+	}
+	return 0644
+}
+
+// Name implements fs.FileInfo
+func (f fileInfo) Name() string { return f.name }
+
+// Size implements fs.FileInfo
+func (f fileInfo) Size() int64 { return f.size }
+
+// Sys implements fs.FileInfo
+func (fileInfo) Sys() any { return nil }
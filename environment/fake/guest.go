@@ -0,0 +1,182 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/abiosoft/colima/config"
+	"github.com/abiosoft/colima/environment"
+)
+
+var _ environment.GuestActions = (*Guest)(nil)
+
+// Guest is an in-memory guest.
+type Guest struct {
+	*recorder
+
+	arch environment.Arch
+	user string
+
+	created  bool
+	running  bool
+	conf     config.Config
+	env      map[string]string
+	settings map[string]string
+}
+
+// NewGuest creates a new in-memory guest that is created and running.
+func NewGuest() *Guest {
+	g := newGuest()
+	g.created = true
+	g.running = true
+	return g
+}
+
+func newGuest() *Guest {
+	return &Guest{
+		recorder: newRecorder(),
+		arch:     environment.HostArch().Value(),
+		user:     "colima",
+		env:      map[string]string{},
+		settings: map[string]string{},
+	}
+}
+
+func (g *Guest) ensureRunning() error {
+	if !g.Running() {
+		return fmt.Errorf("not running")
+	}
+	return nil
+}
+
+// Run implements environment.GuestActions.
+func (g *Guest) Run(args ...string) error {
+	if err := g.ensureRunning(); err != nil {
+		return err
+	}
+	return g.recorder.Run(args...)
+}
+
+// RunQuiet implements environment.GuestActions.
+func (g *Guest) RunQuiet(args ...string) error {
+	if err := g.ensureRunning(); err != nil {
+		return err
+	}
+	return g.recorder.RunQuiet(args...)
+}
+
+// RunOutput implements environment.GuestActions.
+func (g *Guest) RunOutput(args ...string) (string, error) {
+	if err := g.ensureRunning(); err != nil {
+		return "", err
+	}
+	return g.recorder.RunOutput(args...)
+}
+
+// RunInteractive implements environment.GuestActions.
+func (g *Guest) RunInteractive(args ...string) error {
+	if err := g.ensureRunning(); err != nil {
+		return err
+	}
+	return g.recorder.RunInteractive(args...)
+}
+
+// RunWith implements environment.GuestActions.
+func (g *Guest) RunWith(stdin io.Reader, stdout io.Writer, args ...string) error {
+	if err := g.ensureRunning(); err != nil {
+		return err
+	}
+	return g.recorder.RunWith(stdin, stdout, args...)
+}
+
+// Start implements environment.GuestActions.
+func (g *Guest) Start(_ context.Context, conf config.Config) error {
+	g.Lock()
+	defer g.Unlock()
+	if conf.Arch != "" {
+		g.arch = environment.Arch(conf.Arch).Value()
+	}
+	for k, v := range conf.Env {
+		g.env[k] = v
+	}
+	g.conf = conf
+	g.created = true
+	g.running = true
+	return nil
+}
+
+// Stop implements environment.GuestActions.
+func (g *Guest) Stop(context.Context, bool) error {
+	g.Lock()
+	defer g.Unlock()
+	g.running = false
+	return nil
+}
+
+// Restart implements environment.GuestActions.
+func (g *Guest) Restart(ctx context.Context) error {
+	g.Lock()
+	conf := g.conf
+	g.Unlock()
+
+	if conf.Empty() {
+		return fmt.Errorf("cannot restart, VM not previously started")
+	}
+	if err := g.Stop(ctx, false); err != nil {
+		return err
+	}
+	return g.Start(ctx, conf)
+}
+
+// Created implements environment.GuestActions.
+func (g *Guest) Created() bool {
+	g.Lock()
+	defer g.Unlock()
+	return g.created
+}
+
+// Running implements environment.GuestActions.
+func (g *Guest) Running() bool {
+	g.Lock()
+	defer g.Unlock()
+	return g.running
+}
+
+// Env implements environment.GuestActions.
+func (g *Guest) Env(s string) (string, error) {
+	if err := g.ensureRunning(); err != nil {
+		return "", err
+	}
+	g.Lock()
+	defer g.Unlock()
+	return g.env[s], nil
+}
+
+// Get implements environment.GuestActions.
+func (g *Guest) Get(key string) string {
+	g.Lock()
+	defer g.Unlock()
+	return g.settings[key]
+}
+
+// Set implements environment.GuestActions.
+func (g *Guest) Set(key, value string) error {
+	g.Lock()
+	defer g.Unlock()
+	g.settings[key] = value
+	return nil
+}
+
+// User implements environment.GuestActions.
+func (g *Guest) User() (string, error) { return g.user, nil }
+
+// Arch implements environment.GuestActions.
+func (g *Guest) Arch() environment.Arch { return g.arch }
+
+// Config returns the config of the last startup.
+func (g *Guest) Config() config.Config {
+	g.Lock()
+	defer g.Unlock()
+	return g.conf
+}
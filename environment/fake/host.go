@@ -0,0 +1,41 @@
+package fake
+
+import (
+	"strings"
+
+	"github.com/abiosoft/colima/environment"
+)
+
+var _ environment.HostActions = (*Host)(nil)
+
+// Host is an in-memory host.
+type Host struct {
+	*recorder
+	env []string
+}
+
+// NewHost creates a new in-memory host.
+func NewHost() *Host {
+	return &Host{recorder: newRecorder()}
+}
+
+// WithEnv implements environment.HostActions.
+// The new instance shares the recorded commands and files of the current instance.
+func (h *Host) WithEnv(env ...string) environment.HostActions {
+	var newHost Host
+	newHost.recorder = h.recorder
+	newHost.env = append(newHost.env, h.env...)
+	newHost.env = append(newHost.env, env...)
+	return &newHost
+}
+
+// Env implements environment.HostActions.
+func (h *Host) Env(s string) string {
+	// the most recent value takes precedence
+	for i := len(h.env) - 1; i >= 0; i-- {
+		if key, val, ok := strings.Cut(h.env[i], "="); ok && key == s {
+			return val
+		}
+	}
+	return ""
+}
@@ -0,0 +1,105 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/abiosoft/colima/config"
+	"github.com/abiosoft/colima/environment"
+)
+
+var _ environment.Snapshotter = (*VM)(nil)
+
+// snapshot is an in-memory snapshot with a copy of the files.
+type snapshot struct {
+	environment.Snapshot
+	files map[string]string
+}
+
+func copyMap(m map[string]string) map[string]string {
+	c := make(map[string]string, len(m))
+	for k, v := range m {
+		c[k] = v
+	}
+	return c
+}
+
+// Snapshots implements environment.Snapshotter.
+func (v *VM) Snapshots() ([]environment.Snapshot, error) {
+	v.Lock()
+	defer v.Unlock()
+
+	var snapshots []environment.Snapshot
+	for _, s := range v.snapshots {
+		snapshots = append(snapshots, s.Snapshot)
+	}
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].Created.Before(snapshots[j].Created)
+	})
+	return snapshots, nil
+}
+
+// CreateSnapshot implements environment.Snapshotter.
+func (v *VM) CreateSnapshot(ctx context.Context, name string, conf config.Config) error {
+	if !v.Created() {
+		return fmt.Errorf("instance not created")
+	}
+	if err := v.Stop(ctx, false); err != nil {
+		return err
+	}
+
+	v.Lock()
+	defer v.Unlock()
+	if _, ok := v.snapshots[name]; ok {
+		return fmt.Errorf("snapshot '%s' already exists", name)
+	}
+
+	var size int64
+	for _, body := range v.files {
+		size += int64(len(body))
+	}
+	v.snapshots[name] = snapshot{
+		Snapshot: environment.Snapshot{
+			Name:     name,
+			Created:  time.Now(),
+			Size:     size,
+			Config:   conf,
+			Settings: copyMap(v.settings),
+		},
+		files: copyMap(v.files),
+	}
+	return nil
+}
+
+// RestoreSnapshot implements environment.Snapshotter.
+func (v *VM) RestoreSnapshot(ctx context.Context, name string) (environment.Snapshot, error) {
+	v.Lock()
+	s, ok := v.snapshots[name]
+	v.Unlock()
+	if !ok {
+		return environment.Snapshot{}, fmt.Errorf("snapshot '%s' does not exist", name)
+	}
+
+	if err := v.Stop(ctx, false); err != nil {
+		return s.Snapshot, err
+	}
+
+	v.Lock()
+	defer v.Unlock()
+	v.files = copyMap(s.files)
+	v.settings = copyMap(s.Settings)
+	return s.Snapshot, nil
+}
+
+// DeleteSnapshot implements environment.Snapshotter.
+func (v *VM) DeleteSnapshot(name string) error {
+	v.Lock()
+	defer v.Unlock()
+	if _, ok := v.snapshots[name]; !ok {
+		return fmt.Errorf("snapshot '%s' does not exist", name)
+	}
+	delete(v.snapshots, name)
+	return nil
+}
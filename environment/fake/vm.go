@@ -0,0 +1,56 @@
+package fake
+
+import (
+	"context"
+
+	"github.com/abiosoft/colima/environment"
+)
+
+// Name is the VM type name of the in-memory VM.
+// It is only available when this package is imported.
+const Name = "fake"
+
+func init() {
+	environment.RegisterVM(Name, New)
+}
+
+var _ environment.VM = (*VM)(nil)
+
+// VM is an in-memory VM.
+type VM struct {
+	*Guest
+	host      environment.HostActions
+	snapshots map[string]snapshot
+}
+
+// New creates a new in-memory VM that is yet to be created.
+func New(host environment.HostActions) environment.VM {
+	return NewVM(host)
+}
+
+// NewVM is like New but returns the concrete type.
+func NewVM(host environment.HostActions) *VM {
+	return &VM{
+		Guest:     newGuest(),
+		host:      host,
+		snapshots: map[string]snapshot{},
+	}
+}
+
+// Host implements environment.VM.
+func (v *VM) Host() environment.HostActions { return v.host }
+
+// Teardown implements environment.VM.
+func (v *VM) Teardown(context.Context) error {
+	v.Lock()
+	defer v.Unlock()
+	v.created = false
+	v.running = false
+	v.settings = map[string]string{}
+	v.files = map[string]string{}
+	v.snapshots = map[string]snapshot{}
+	return nil
+}
+
+// Dependencies implements environment.VM.
+func (v *VM) Dependencies() []string { return nil }
@@ -2,7 +2,12 @@ package environment
 
 import (
 	"context"
+	"fmt"
+	"log"
 	"runtime"
+	"time"
+
+	"github.com/abiosoft/colima/config"
 )
 
 // VM is virtual machine.
@@ -13,6 +18,69 @@ type VM interface {
 	Teardown(ctx context.Context) error
 }
 
+// Snapshot is a point-in-time copy of a VM.
+type Snapshot struct {
+	Name    string    `json:"name"`
+	Created time.Time `json:"created"`
+	// Size is the size of the disk copy in bytes.
+	Size int64 `json:"size"`
+	// Config is the colima config at the time of the snapshot.
+	Config config.Config `json:"-"`
+	// Settings are the VM settings at the time of the snapshot.
+	Settings map[string]string `json:"settings,omitempty"`
+}
+
+// Snapshotter is implemented by VMs that support snapshots.
+type Snapshotter interface {
+	// Snapshots returns the snapshots of the VM, ordered by creation time.
+	Snapshots() ([]Snapshot, error)
+	// CreateSnapshot stops the VM (if running) and snapshots the disk and settings alongside conf.
+	CreateSnapshot(ctx context.Context, name string, conf config.Config) error
+	// RestoreSnapshot stops the VM (if running) and restores the snapshot.
+	// The settings are restored on the next startup of the VM.
+	RestoreSnapshot(ctx context.Context, name string) (Snapshot, error)
+	// DeleteSnapshot deletes the snapshot.
+	DeleteSnapshot(name string) error
+}
+
+// Archivable is implemented by VMs that can be exported to and imported from an archive.
+type Archivable interface {
+	// ArchiveDir returns the directory of the VM files.
+	ArchiveDir() string
+	// ArchiveFiles returns the names of the files in ArchiveDir that make up the VM.
+	ArchiveFiles() []string
+}
+
+// NewVM creates a new VM.
+func NewVM(vmType string, host HostActions) (VM, error) {
+	if _, ok := vmTypes[vmType]; !ok {
+		return nil, fmt.Errorf("unsupported vm type '%s'", vmType)
+	}
+
+	return vmTypes[vmType](host), nil
+}
+
+// NewVMFunc is implemented by VM implementations to create a new instance.
+type NewVMFunc func(host HostActions) VM
+
+var vmTypes = map[string]NewVMFunc{}
+
+// RegisterVM registers a new VM type.
+func RegisterVM(name string, f NewVMFunc) {
+	if _, ok := vmTypes[name]; ok {
+		log.Fatalf("vm type '%s' already registered", name)
+	}
+	vmTypes[name] = f
+}
+
+// VMTypes return the names of available VM types.
+func VMTypes() (names []string) {
+	for name := range vmTypes {
+		names = append(names, name)
+	}
+	return
+}
+
 // VM configurations
 const (
 	// ContainerRuntimeKey is the settings key for container runtime.
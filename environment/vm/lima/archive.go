@@ -0,0 +1,14 @@
+package lima
+
+import "github.com/abiosoft/colima/environment"
+
+var _ environment.Archivable = (*limaVM)(nil)
+
+// ArchiveDir implements environment.Archivable.
+func (l limaVM) ArchiveDir() string { return l.limaConfDir() }
+
+// ArchiveFiles implements environment.Archivable.
+// Runtime files e.g. sockets, pids and logs are recreated by Lima on startup.
+func (l limaVM) ArchiveFiles() []string {
+	return []string{"basedisk", diskFile, limaConfigFile}
+}
@@ -0,0 +1,81 @@
+package lima
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/abiosoft/colima/config"
+	"gopkg.in/yaml.v3"
+)
+
+// dataVolumeLabel is the filesystem label of the VM disk.
+const dataVolumeLabel = "data-volume"
+
+// diskSize returns the disk size in GiB of the created instance.
+// It returns zero if the size cannot be determined.
+func (l limaVM) diskSize() (int, error) {
+	b, err := os.ReadFile(filepath.Join(l.limaConfDir(), limaConfigFile))
+	if err != nil {
+		return 0, fmt.Errorf("error reading lima config: %w", err)
+	}
+
+	var conf Config
+	if err := yaml.Unmarshal(b, &conf); err != nil {
+		return 0, fmt.Errorf("error parsing lima config: %w", err)
+	}
+
+	return parseDiskSize(conf.Disk), nil
+}
+
+// parseDiskSize parses the GiB disk size in the format written by newConf.
+func parseDiskSize(s string) int {
+	size, err := strconv.Atoi(strings.TrimSuffix(s, "GiB"))
+	if err != nil {
+		return 0
+	}
+	return size
+}
+
+// syncDiskSize grows the disk of the stopped instance if a larger size is specified in conf.
+// Disks cannot be shrunk, a smaller size is replaced with the current size.
+// The returned bool reports whether the disk was resized.
+func (l limaVM) syncDiskSize(conf config.Config) (config.Config, bool, error) {
+	log := l.Logger()
+
+	current, err := l.diskSize()
+	if err != nil {
+		return conf, false, err
+	}
+	if current == 0 || conf.Disk == 0 || conf.Disk == current {
+		return conf, false, nil
+	}
+
+	if conf.Disk < current {
+		log.Warnf("disk size cannot be reduced from %dGiB to %dGiB, ignoring", current, conf.Disk)
+		conf.Disk = current
+		return conf, false, nil
+	}
+
+	log.Printf("resizing disk from %dGiB to %dGiB", current, conf.Disk)
+	disk := filepath.Join(l.limaConfDir(), diskFile)
+	if err := l.host.RunQuiet("qemu-img", "resize", disk, fmt.Sprintf("%dG", conf.Disk)); err != nil {
+		return conf, false, fmt.Errorf("error resizing disk: %w", err)
+	}
+
+	return conf, true, nil
+}
+
+// growFilesystem grows the filesystem of the running instance to fill the resized disk.
+func (l limaVM) growFilesystem() error {
+	device, err := l.RunOutput("sudo", "findfs", "LABEL="+dataVolumeLabel)
+	if err != nil {
+		return fmt.Errorf("error locating disk: %w", err)
+	}
+	if err := l.RunQuiet("sudo", "resize2fs", device); err != nil {
+		return fmt.Errorf("error growing filesystem on %s: %w", device, err)
+	}
+	return nil
+}
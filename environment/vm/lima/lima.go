@@ -23,6 +23,13 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
+// Name is the VM type name.
+const Name = "lima"
+
+func init() {
+	environment.RegisterVM(Name, New)
+}
+
 // New creates a new virtual machine.
 func New(host environment.HostActions) environment.VM {
 	var envs []string
@@ -41,7 +48,6 @@ func New(host environment.HostActions) environment.VM {
 		home = filepath.Join(util.HomeDir(), ".lima")
 	}
 
-	// consider making this truly flexible to support other VMs
 	return &limaVM{
 		host:         host.WithEnv(envs...),
 		home:         home,
@@ -225,6 +231,11 @@ func (l *limaVM) Start(ctx context.Context, conf config.Config) error {
 	// registry certs
 	a.Add(l.copyCerts)
 
+	// provision scripts output
+	a.Add(func() error {
+		return l.logProvisionOutput(conf)
+	})
+
 	// dns
 	l.applyDNS(ctx, a, conf)
 
@@ -251,6 +262,13 @@ func (l limaVM) resume(ctx context.Context, conf config.Config) error {
 		return err
 	})
 
+	// disk size can only be changed while stopped
+	var diskResized bool
+	a.Add(func() (err error) {
+		conf, diskResized, err = l.syncDiskSize(conf)
+		return err
+	})
+
 	configFile := filepath.Join(l.limaConfDir(), "lima.yaml")
 
 	a.Add(func() error {
@@ -266,9 +284,28 @@ func (l limaVM) resume(ctx context.Context, conf config.Config) error {
 		return l.host.Run(limactl, "start", config.Profile().ID)
 	})
 
+	// settings of a restored snapshot
+	a.Add(l.applyPendingSettings)
+
+	// the filesystem is grown online after a disk resize
+	a.Add(func() error {
+		if !diskResized {
+			return nil
+		}
+		if err := l.growFilesystem(); err != nil {
+			log.Warnln(fmt.Errorf("disk resized but filesystem not grown: %w", err))
+		}
+		return nil
+	})
+
 	// registry certs
 	a.Add(l.copyCerts)
 
+	// provision scripts output
+	a.Add(func() error {
+		return l.logProvisionOutput(conf)
+	})
+
 	l.applyDNS(ctx, a, conf)
 
 	return a.Exec()
@@ -0,0 +1,85 @@
+package network
+
+import (
+	"errors"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/abiosoft/colima/environment/fake"
+)
+
+func Test_limaDNSManager_Provision(t *testing.T) {
+	guest := fake.NewGuest()
+	// default resolv file not yet backed up
+	guest.Respond("stat "+dnsResolvFileLima, "", errors.New("no such file"))
+
+	d := NewDNSManager(guest)
+	if err := d.Provision([]net.IP{net.ParseIP("8.8.8.8")}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"stat /etc/resolv/lima/resolv.conf",
+		"sudo mkdir -p /etc/resolv/colima /etc/resolv/lima",
+		"sudo cp /etc/resolv.conf /etc/resolv/lima/resolv.conf",
+	}
+	if got := guest.Commands(); !reflect.DeepEqual(got, want) {
+		t.Errorf("commands:\ngot  %q\nwant %q", got, want)
+	}
+
+	if _, err := guest.Stat(coreDNSFile); err != nil {
+		t.Errorf("coredns config not written: %v", err)
+	}
+	resolv, err := guest.Read(dnsResolvFileColima)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(resolv, "nameserver 8.8.8.8") {
+		t.Errorf("nameserver missing from %s:\n%s", dnsResolvFileColima, resolv)
+	}
+}
+
+func Test_limaDNSManager_Start(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "started",
+			want: []string{"sudo service coredns start"},
+		},
+		{
+			name: "failed",
+			err:  errors.New("exit status 1"),
+			want: []string{
+				"sudo service coredns start",
+				"sudo cp /etc/resolv/colima/resolv.conf /etc/resolv.conf",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			guest := fake.NewGuest()
+			guest.Respond("sudo service coredns start", "", tt.err)
+
+			d := NewDNSManager(guest)
+			if err := d.Start(); (err != nil) != tt.wantErr {
+				t.Fatalf("Start() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got := guest.Commands(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("commands:\ngot  %q\nwant %q", got, tt.want)
+			}
+
+			resolv, _ := guest.Read(dnsResolvFile)
+			if got := strings.Contains(resolv, "nameserver "+qemuSlirpIP); got == tt.wantErr {
+				t.Errorf("resolv.conf nameserver set = %v, want %v", got, !tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package lima
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/abiosoft/colima/config"
+	"github.com/abiosoft/colima/embedded"
+	"github.com/abiosoft/colima/util"
+)
+
+const (
+	provisionLogDir    = "/var/log/colima/provision"
+	provisionMarkerDir = "/var/lib/colima/provision"
+)
+
+func provisionLogFile(index int) string {
+	return path.Join(provisionLogDir, fmt.Sprintf("%d.log", index))
+}
+
+// userProvisionScripts converts the provision scripts in conf to Lima provision scripts.
+// The scripts are wrapped to capture the output and to only run once where specified.
+func userProvisionScripts(conf config.Config) ([]Provision, error) {
+	if len(conf.Provision) == 0 {
+		return nil, nil
+	}
+
+	tpl, err := embedded.ReadString("provision/provision.sh")
+	if err != nil {
+		return nil, fmt.Errorf("error reading provision script template: %w", err)
+	}
+
+	var provisions []Provision
+	for i, p := range conf.Provision {
+		mode := p.Mode
+		switch mode {
+		case "":
+			mode = ProvisionModeSystem
+		case config.ProvisionModeSystem, config.ProvisionModeUser:
+		default:
+			return nil, fmt.Errorf("invalid mode '%s' for provision script %d", p.Mode, i)
+		}
+
+		values := struct {
+			Index      int
+			Script     string
+			Once       bool
+			LogFile    string
+			MarkerFile string
+		}{
+			Index:   i,
+			Script:  strings.TrimSpace(p.Script),
+			Once:    p.Once,
+			LogFile: provisionLogFile(i),
+			// a modified script is a new script
+			MarkerFile: path.Join(provisionMarkerDir, util.SHA256Hash(mode+"\n"+p.Script).String()),
+		}
+		script, err := util.ParseTemplate(tpl, values)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing template for provision script %d: %w", i, err)
+		}
+
+		provisions = append(provisions, Provision{
+			Mode:   mode,
+			Script: string(script),
+		})
+	}
+
+	return provisions, nil
+}
+
+// logProvisionOutput logs the output of the provision scripts that ran during the last boot.
+func (l limaVM) logProvisionOutput(conf config.Config) error {
+	log := l.Logger()
+
+	for i := range conf.Provision {
+		file := provisionLogFile(i)
+		// scripts that did not run have no output
+		if err := l.RunQuiet("sudo", "test", "-f", file); err != nil {
+			continue
+		}
+
+		out, err := l.Read(file)
+		if err != nil {
+			log.Warnln(fmt.Errorf("error reading output of provision script %d: %w", i, err))
+			continue
+		}
+		for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+			if line != "" {
+				log.Printf("provision[%d]: %s", i, line)
+			}
+		}
+
+		// prevent output from being displayed again on the next boot
+		if err := l.RunQuiet("sudo", "rm", "-f", file); err != nil {
+			log.Warnln(fmt.Errorf("error removing output of provision script %d: %w", i, err))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,240 @@
+package lima
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/abiosoft/colima/config"
+	"github.com/abiosoft/colima/config/configmanager"
+	"github.com/abiosoft/colima/environment"
+	"github.com/abiosoft/colima/util"
+)
+
+var _ environment.Snapshotter = (*limaVM)(nil)
+
+const (
+	snapshotsDirName     = "snapshots"
+	snapshotInfoFile     = "snapshot.json"
+	snapshotConfigFile   = "colima.yaml"
+	pendingSettingsFile  = "colima-settings.json"
+	diskFile             = "diffdisk"
+	limaConfigFile       = "lima.yaml"
+	snapshotTmpDirPrefix = ".tmp-"
+)
+
+func (l limaVM) snapshotsDir() string {
+	return filepath.Join(l.limaConfDir(), snapshotsDirName)
+}
+
+func (l limaVM) snapshotDir(name string) string {
+	return filepath.Join(l.snapshotsDir(), name)
+}
+
+// copyFile copies a file on the host, cloning where supported by the filesystem.
+func (l limaVM) copyFile(src, dst string) error {
+	args := []string{"cp", "--reflink=auto", src, dst}
+	if util.MacOS() {
+		// clonefile on APFS, falls back to a regular copy
+		args = []string{"cp", "-c", src, dst}
+	}
+	if err := l.host.RunQuiet(args...); err != nil {
+		return fmt.Errorf("error copying '%s': %w", src, err)
+	}
+	return nil
+}
+
+// Snapshots implements environment.Snapshotter.
+func (l limaVM) Snapshots() ([]environment.Snapshot, error) {
+	entries, err := os.ReadDir(l.snapshotsDir())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error reading snapshots: %w", err)
+	}
+
+	var snapshots []environment.Snapshot
+	for _, entry := range entries {
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		s, err := l.snapshot(entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, s)
+	}
+
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].Created.Before(snapshots[j].Created)
+	})
+
+	return snapshots, nil
+}
+
+func (l limaVM) snapshot(name string) (environment.Snapshot, error) {
+	var s environment.Snapshot
+	dir := l.snapshotDir(name)
+
+	b, err := os.ReadFile(filepath.Join(dir, snapshotInfoFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return s, fmt.Errorf("snapshot '%s' does not exist", name)
+		}
+		return s, fmt.Errorf("error reading snapshot '%s': %w", name, err)
+	}
+	if err := json.Unmarshal(b, &s); err != nil {
+		return s, fmt.Errorf("error reading snapshot '%s': %w", name, err)
+	}
+
+	s.Config, err = configmanager.LoadFrom(filepath.Join(dir, snapshotConfigFile))
+	if err != nil {
+		return s, fmt.Errorf("error reading snapshot '%s': %w", name, err)
+	}
+
+	return s, nil
+}
+
+// CreateSnapshot implements environment.Snapshotter.
+func (l limaVM) CreateSnapshot(ctx context.Context, name string, conf config.Config) error {
+	if !l.Created() {
+		return fmt.Errorf("instance not created")
+	}
+	if _, err := os.Stat(l.snapshotDir(name)); err == nil {
+		return fmt.Errorf("snapshot '%s' already exists", name)
+	}
+
+	s := environment.Snapshot{Name: name}
+
+	// settings are only retrievable while running
+	if l.Running() {
+		s.Settings = l.getConf()
+	}
+
+	if err := l.Stop(ctx, false); err != nil {
+		return err
+	}
+
+	// populate a temporary directory to prevent partial snapshots
+	tmpDir := l.snapshotDir(snapshotTmpDirPrefix + name)
+	if err := os.MkdirAll(tmpDir, 0755); err != nil {
+		return fmt.Errorf("error creating snapshot directory: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+
+	log := l.Logger()
+	log.Println("copying disk")
+	for _, file := range []string{diskFile, limaConfigFile} {
+		if err := l.copyFile(filepath.Join(l.limaConfDir(), file), filepath.Join(tmpDir, file)); err != nil {
+			return err
+		}
+	}
+
+	if err := configmanager.SaveToFile(conf, filepath.Join(tmpDir, snapshotConfigFile)); err != nil {
+		return fmt.Errorf("error saving snapshot config: %w", err)
+	}
+
+	if stat, err := os.Stat(filepath.Join(tmpDir, diskFile)); err == nil {
+		s.Size = stat.Size()
+	}
+	s.Created = time.Now()
+	b, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding snapshot info: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, snapshotInfoFile), b, 0644); err != nil {
+		return fmt.Errorf("error saving snapshot info: %w", err)
+	}
+
+	if err := os.Rename(tmpDir, l.snapshotDir(name)); err != nil {
+		return fmt.Errorf("error saving snapshot: %w", err)
+	}
+
+	return nil
+}
+
+// RestoreSnapshot implements environment.Snapshotter.
+func (l limaVM) RestoreSnapshot(ctx context.Context, name string) (environment.Snapshot, error) {
+	s, err := l.snapshot(name)
+	if err != nil {
+		return s, err
+	}
+
+	if err := l.Stop(ctx, false); err != nil {
+		return s, err
+	}
+
+	// copy alongside the instance files and rename for an atomic swap
+	log := l.Logger()
+	log.Println("restoring disk")
+	files := []string{diskFile, limaConfigFile}
+	for _, file := range files {
+		src := filepath.Join(l.snapshotDir(name), file)
+		tmp := filepath.Join(l.limaConfDir(), snapshotTmpDirPrefix+file)
+		if err := l.copyFile(src, tmp); err != nil {
+			for _, f := range files {
+				_ = os.Remove(filepath.Join(l.limaConfDir(), snapshotTmpDirPrefix+f))
+			}
+			return s, err
+		}
+	}
+	for _, file := range files {
+		tmp := filepath.Join(l.limaConfDir(), snapshotTmpDirPrefix+file)
+		if err := os.Rename(tmp, filepath.Join(l.limaConfDir(), file)); err != nil {
+			return s, fmt.Errorf("error restoring snapshot: %w", err)
+		}
+	}
+
+	// settings can only be written to the running VM, defer to startup
+	if len(s.Settings) > 0 {
+		b, err := json.Marshal(s.Settings)
+		if err != nil {
+			return s, fmt.Errorf("error encoding snapshot settings: %w", err)
+		}
+		if err := os.WriteFile(filepath.Join(l.limaConfDir(), pendingSettingsFile), b, 0644); err != nil {
+			return s, fmt.Errorf("error saving snapshot settings: %w", err)
+		}
+	}
+
+	return s, nil
+}
+
+// DeleteSnapshot implements environment.Snapshotter.
+func (l limaVM) DeleteSnapshot(name string) error {
+	if _, err := l.snapshot(name); err != nil {
+		return err
+	}
+	if err := os.RemoveAll(l.snapshotDir(name)); err != nil {
+		return fmt.Errorf("error deleting snapshot '%s': %w", name, err)
+	}
+	return nil
+}
+
+// applyPendingSettings writes the settings of a restored snapshot to the running VM.
+func (l limaVM) applyPendingSettings() error {
+	file := filepath.Join(l.limaConfDir(), pendingSettingsFile)
+	b, err := os.ReadFile(file)
+	if err != nil {
+		// nothing pending
+		return nil
+	}
+
+	var settings map[string]string
+	if err := json.Unmarshal(b, &settings); err != nil {
+		return fmt.Errorf("error reading snapshot settings: %w", err)
+	}
+	if err := l.Run("sudo", "mkdir", "-p", filepath.Dir(configFile)); err != nil {
+		return fmt.Errorf("error restoring snapshot settings: %w", err)
+	}
+	if err := l.Write(configFile, string(b)); err != nil {
+		return fmt.Errorf("error restoring snapshot settings: %w", err)
+	}
+
+	return os.Remove(file)
+}
@@ -173,6 +173,13 @@ func newConf(ctx context.Context, conf config.Config) (l Config, err error) {
 
 	}
 
+	// user provision scripts come last, after the network is configured
+	provisions, err := userProvisionScripts(conf)
+	if err != nil {
+		return l, err
+	}
+	l.Provision = append(l.Provision, provisions...)
+
 	// disable ports 80 and 443 when k8s is enabled and there is a reachable IP address
 	// to prevent ingress (traefik) from occupying relevant host ports.
 	if vmnetEnabled && conf.Kubernetes.Enabled && conf.Kubernetes.Ingress {
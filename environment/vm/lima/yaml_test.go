@@ -95,3 +95,43 @@ func Test_config_Mounts(t *testing.T) {
 		})
 	}
 }
+
+func Test_userProvisionScripts(t *testing.T) {
+	tests := []struct {
+		provision config.Provision
+		wantMode  ProvisionMode
+		wantOnce  bool
+		wantErr   bool
+	}{
+		{provision: config.Provision{Script: "apk add htop"}, wantMode: ProvisionModeSystem},
+		{provision: config.Provision{Mode: "user", Script: "git config --global init.defaultBranch main", Once: true}, wantMode: ProvisionModeUser, wantOnce: true},
+		{provision: config.Provision{Mode: "root", Script: "true"}, wantErr: true},
+	}
+	for i, tt := range tests {
+		t.Run(fmt.Sprint(i), func(t *testing.T) {
+			conf := config.Config{Provision: []config.Provision{tt.provision}}
+			got, err := userProvisionScripts(conf)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("userProvisionScripts() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(got) != 1 {
+				t.Fatalf("userProvisionScripts() returned %d scripts, want 1", len(got))
+			}
+			if got[0].Mode != tt.wantMode {
+				t.Errorf("mode = %s, want %s", got[0].Mode, tt.wantMode)
+			}
+			if !strings.Contains(got[0].Script, tt.provision.Script) {
+				t.Errorf("script missing from:\n%s", got[0].Script)
+			}
+			if !strings.Contains(got[0].Script, provisionLogFile(0)) {
+				t.Errorf("log file missing from:\n%s", got[0].Script)
+			}
+			if once := strings.Contains(got[0].Script, `[ -f "$marker" ] && exit 0`); once != tt.wantOnce {
+				t.Errorf("once = %v, want %v", once, tt.wantOnce)
+			}
+		})
+	}
+}